@@ -0,0 +1,110 @@
+package cloudevent
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Cache de-duplicates cloud events triggered by reconciles that requeue
+// without a real state transition. It is keyed by the UID of the object the
+// event was derived from, plus the `Succeeded` condition's status, reason
+// and message.
+type Cache interface {
+	// ShouldSend reports whether an event for this condition tuple should be
+	// sent, i.e. it differs from the last tuple recorded by MarkSent for uid.
+	ShouldSend(uid types.UID, status, reason, message string) bool
+	// MarkSent records a condition tuple for uid, so that a subsequent
+	// identical tuple is suppressed. SendCloudEventWithRetries calls it once
+	// it has decided to send, before delivery runs, so a tuple whose delivery
+	// then fails is still suppressed on the next identical reconcile.
+	MarkSent(uid types.UID, status, reason, message string)
+}
+
+// conditionKey returns the value stored per UID for a `Succeeded` condition's
+// status, reason and message; the UID itself is the key of the LRU.
+func conditionKey(status, reason, message string) string {
+	return fmt.Sprintf("%s|%s|%s", status, reason, message)
+}
+
+// NoopCache never suppresses an event. It is the Cache used by tests and by
+// callers that have not opted into de-duplication.
+type NoopCache struct{}
+
+// ShouldSend implements Cache.
+func (NoopCache) ShouldSend(types.UID, string, string, string) bool { return true }
+
+// MarkSent implements Cache.
+func (NoopCache) MarkSent(types.UID, string, string, string) {}
+
+type cacheEntry struct {
+	key       string
+	expiresAt time.Time
+}
+
+// lruTTLCache is the bounded, in-memory Cache implementation used in
+// production. It wraps an LRU of a fixed size with a TTL on each entry so
+// that an object's last-sent condition is eventually forgotten even if the
+// object itself is never reconciled again.
+type lruTTLCache struct {
+	mu    sync.Mutex
+	ttl   time.Duration
+	inner *lru.Cache
+}
+
+// NewLRUCache returns a Cache bounded to size entries, each remembered for
+// ttl before being treated as stale (and so no longer suppressing a resend).
+func NewLRUCache(size int, ttl time.Duration) (Cache, error) {
+	inner, err := lru.New(size)
+	if err != nil {
+		return nil, fmt.Errorf("creating cloudevent de-duplication cache: %w", err)
+	}
+	return &lruTTLCache{ttl: ttl, inner: inner}, nil
+}
+
+// ShouldSend implements Cache.
+func (c *lruTTLCache) ShouldSend(uid types.UID, status, reason, message string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.inner.Get(uid)
+	if !ok {
+		return true
+	}
+	entry := v.(cacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		return true
+	}
+	return entry.key != conditionKey(status, reason, message)
+}
+
+// MarkSent implements Cache.
+func (c *lruTTLCache) MarkSent(uid types.UID, status, reason, message string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inner.Add(uid, cacheEntry{
+		key:       conditionKey(status, reason, message),
+		expiresAt: time.Now().Add(c.ttl),
+	})
+}
+
+type cacheKey struct{}
+
+// WithCache returns a copy of ctx carrying c, retrievable with
+// CacheFromContext.
+func WithCache(ctx context.Context, c Cache) context.Context {
+	return context.WithValue(ctx, cacheKey{}, c)
+}
+
+// CacheFromContext returns the Cache stored in ctx by WithCache, or a
+// NoopCache if none was set, so that callers who have not configured a
+// cache keep today's send-every-time behaviour.
+func CacheFromContext(ctx context.Context) Cache {
+	if c, ok := ctx.Value(cacheKey{}).(Cache); ok {
+		return c
+	}
+	return NoopCache{}
+}
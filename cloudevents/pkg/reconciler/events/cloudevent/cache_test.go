@@ -0,0 +1,55 @@
+package cloudevent
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestLRUTTLCacheSuppressesIdenticalCondition(t *testing.T) {
+	c, err := NewLRUCache(10, time.Minute)
+	if err != nil {
+		t.Fatalf("NewLRUCache: %v", err)
+	}
+	uid := types.UID("abc-123")
+
+	if !c.ShouldSend(uid, "True", "Succeeded", "done") {
+		t.Fatal("expected first send for a UID to be allowed")
+	}
+	c.MarkSent(uid, "True", "Succeeded", "done")
+
+	if c.ShouldSend(uid, "True", "Succeeded", "done") {
+		t.Fatal("expected an identical condition tuple to be suppressed")
+	}
+	if !c.ShouldSend(uid, "False", "Failed", "oops") {
+		t.Fatal("expected a changed condition tuple to be allowed")
+	}
+}
+
+func TestLRUTTLCacheExpiresStaleEntries(t *testing.T) {
+	c, err := NewLRUCache(10, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewLRUCache: %v", err)
+	}
+	uid := types.UID("abc-123")
+
+	c.MarkSent(uid, "True", "Succeeded", "done")
+	if c.ShouldSend(uid, "True", "Succeeded", "done") {
+		t.Fatal("expected the fresh entry to still suppress a duplicate")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if !c.ShouldSend(uid, "True", "Succeeded", "done") {
+		t.Fatal("expected the entry to expire once past its TTL")
+	}
+}
+
+func TestNoopCacheNeverSuppresses(t *testing.T) {
+	c := NoopCache{}
+	uid := types.UID("abc-123")
+	c.MarkSent(uid, "True", "Succeeded", "done")
+	if !c.ShouldSend(uid, "True", "Succeeded", "done") {
+		t.Fatal("NoopCache must never suppress a send")
+	}
+}
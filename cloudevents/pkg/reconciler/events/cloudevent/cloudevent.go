@@ -9,7 +9,9 @@ import (
 
 	cdeevents "github.com/cdfoundation/sig-events/cde/sdk/go/pkg/cdf/events"
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/protocol"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/run/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -28,8 +30,14 @@ const (
 	StatusError    EventStatus = "Error"
 )
 
-// CEClient matches the `Client` interface from github.com/cloudevents/sdk-go/v2/cloudevents
-type CEClient cloudevents.Client
+// CEClient abstracts delivery of a single CloudEvent over whichever
+// protocol binding a sink is configured for (HTTP, NATS, Kafka). The
+// `cloudevents/sdk-go/v2` Client already satisfies this interface, so
+// NewCEClient builds one per protocol by picking the appropriate binding
+// from `cloudevents/sdk-go/v2/protocol`; see protocol.go.
+type CEClient interface {
+	Send(ctx context.Context, event cloudevents.Event) protocol.Result
+}
 
 type CDECloudEventData map[string]string
 
@@ -49,6 +57,12 @@ func getEventData(runObject objectWithCondition) (CDECloudEventData, error) {
 			return nil, err
 		}
 		cdeCloudEventData["pipelinerun"] = string(data)
+	case *v1alpha1.Run:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		cdeCloudEventData["run"] = string(data)
 	}
 	return cdeCloudEventData, nil
 }
@@ -89,6 +103,19 @@ func getEventType(runObject objectWithCondition) (*EventType, error) {
 			default:
 				return nil, fmt.Errorf("unknown status with unknown reason %s", c.Reason)
 			}
+		// TODO(cdeevents): reusing the TaskRun event types for custom-task
+		// Runs is an MVP; a dedicated RunStarted/RunQueued type set should
+		// be added to the SDK once the CDEvents vocabulary covers them.
+		case *v1alpha1.Run:
+			switch c.Reason {
+			case v1alpha1.RunReasonStarted.String():
+				eventType.Type = cdeevents.TaskRunStartedEventV1
+			case v1alpha1.RunReasonRunning.String():
+				eventType.Type = cdeevents.TaskRunStartedEventV1
+			// Unknown status, unknown reason -> no event type
+			default:
+				return nil, fmt.Errorf("unknown status with unknown reason %s", c.Reason)
+			}
 		}
 	case c.IsTrue():
 		eventType.Status = StatusFinished
@@ -97,6 +124,8 @@ func getEventType(runObject objectWithCondition) (*EventType, error) {
 			eventType.Type = cdeevents.TaskRunFinishedEventV1 //TaskRunFailedEventV1
 		case *v1beta1.PipelineRun:
 			eventType.Type = cdeevents.PipelineRunFinishedEventV1 //PipelineRunFailedEventV1
+		case *v1alpha1.Run:
+			eventType.Type = cdeevents.TaskRunFinishedEventV1
 		}
 	case c.IsFalse():
 		eventType.Status = StatusError
@@ -105,6 +134,8 @@ func getEventType(runObject objectWithCondition) (*EventType, error) {
 			eventType.Type = cdeevents.TaskRunFinishedEventV1 //TaskRunFailedEventV1
 		case *v1beta1.PipelineRun:
 			eventType.Type = cdeevents.PipelineRunFinishedEventV1 //PipelineRunFailedEventV1
+		case *v1alpha1.Run:
+			eventType.Type = cdeevents.TaskRunFinishedEventV1
 		}
 	default:
 		return nil, fmt.Errorf("unknown condition for in %T.Status %s", runObject, c.Status)
@@ -112,9 +143,18 @@ func getEventType(runObject objectWithCondition) (*EventType, error) {
 	return &eventType, nil
 }
 
+// CreateRunEvent builds a CDEvent for a Tekton Run (custom task), analogous
+// to cdeevents.CreateTaskRunEvent/CreatePipelineRunEvent. It is kept local to
+// this package, rather than in the SDK, until a Run-specific CDEventType set
+// lands upstream; today it shares the TaskRun event schema.
+func CreateRunEvent(eventType cdeevents.CDEventType, runUID, runName, pipelineRunName string, data CDECloudEventData) (cloudevents.Event, error) {
+	return cdeevents.CreateTaskRunEvent(eventType, runUID, runName, pipelineRunName, data)
+}
+
 // eventForObjectWithCondition creates a new event based for a objectWithCondition,
-// or return an error if not possible.
-func eventForObjectWithCondition(runObject objectWithCondition) (*cloudevents.Event, error) {
+// or return an error if not possible. When a Signer is present in ctx (see
+// WithSigner), the event is signed before it is returned.
+func eventForObjectWithCondition(ctx context.Context, runObject objectWithCondition) (*cloudevents.Event, error) {
 	var event cloudevents.Event
 	var err error
 	meta := runObject.GetObjectMeta()
@@ -137,6 +177,11 @@ func eventForObjectWithCondition(runObject objectWithCondition) (*cloudevents.Ev
 		if err != nil {
 			return nil, err
 		}
+	case *v1alpha1.Run:
+		event, err = CreateRunEvent(etype.Type, string(meta.GetUID()), meta.GetName(), "", data)
+		if err != nil {
+			return nil, err
+		}
 	}
 	event.SetSubject(runObject.GetObjectMeta().GetName())
 	source := runObject.GetObjectMeta().GetSelfLink()
@@ -151,15 +196,55 @@ func eventForObjectWithCondition(runObject objectWithCondition) (*cloudevents.Ev
 	}
 	event.SetSource(source)
 
+	if signer := SignerFromContext(ctx); signer != nil {
+		if err := SignEvent(ctx, signer, &event); err != nil {
+			return nil, fmt.Errorf("signing cloudevent: %w", err)
+		}
+	}
+
 	return &event, nil
 }
 
+// SendOption customizes a single SendCloudEventWithRetries call.
+type SendOption func(*sendOptions)
+
+type sendOptions struct {
+	force bool
+}
+
+// WithForce bypasses the condition-change cache (see WithCache), forcing the
+// event to be sent even if an identical one was already sent for the same
+// object. Intended for callers that want a manual resync to always produce
+// an event, e.g. a `tkn` resend command.
+func WithForce() SendOption {
+	return func(o *sendOptions) {
+		o.force = true
+	}
+}
+
 // SendCloudEventWithRetries sends a cloud event for the specified resource.
-// It does not block and it perform retries with backoff using the cloudevents
-// sdk-go capabilities.
+// It does not block and it performs retries with backoff in the background:
+// through the Dispatcher's own per-sink retry loop, or through the
+// cloudevents sdk-go capabilities on the legacy single-client path.
 // It accepts a runtime.Object to avoid making objectWithCondition public since
 // it's only used within the events/cloudevents packages.
-func SendCloudEventWithRetries(ctx context.Context, object runtime.Object) error {
+//
+// Delivery fans out to every sink configured on the Dispatcher found in ctx
+// (see WithDispatcher), which also tracks per-sink delivery state on the
+// object's status and forwards permanently-failed events to a dead-letter
+// sink. Controllers that have not migrated to a Dispatcher yet fall back to
+// the legacy single-client behaviour via cloudevent.Get(ctx).
+//
+// Before sending, the object's UID and its `Succeeded` condition's
+// status/reason/message are checked against the Cache found in ctx (see
+// WithCache); an identical tuple to the last one sent for this UID is
+// suppressed unless WithForce is passed. The cache entry is recorded as soon
+// as the decision to send is made, not after delivery completes: delivery
+// happens asynchronously and can take seconds with retries, while the next
+// reconcile's requeue can land within milliseconds, so waiting for delivery
+// to finish before updating the cache would leave that window wide open to
+// the exact duplicate-event race this cache exists to close.
+func SendCloudEventWithRetries(ctx context.Context, object runtime.Object, opts ...SendOption) error {
 	var (
 		o  objectWithCondition
 		ok bool
@@ -167,28 +252,70 @@ func SendCloudEventWithRetries(ctx context.Context, object runtime.Object) error
 	if o, ok = object.(objectWithCondition); !ok {
 		return errors.New("input object does not satisfy objectWithCondition")
 	}
+	options := sendOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	logger := logging.FromContext(ctx)
-	ceClient := cloudevent.Get(ctx)
-	if ceClient == nil {
-		return errors.New("no cloud events client found in the context")
+	uid := o.GetObjectMeta().GetUID()
+	condition := o.GetStatusCondition().GetCondition(apis.ConditionSucceeded)
+
+	cache := CacheFromContext(ctx)
+	if condition != nil && !options.force && !cache.ShouldSend(uid, string(condition.Status), condition.Reason, condition.Message) {
+		logger.Debugf("Suppressing duplicate cloudevent for %s %s: condition unchanged", o.GetObjectKind().GroupVersionKind().Kind, uid)
+		return nil
 	}
-	event, err := eventForObjectWithCondition(o)
+
+	event, err := eventForObjectWithCondition(ctx, o)
 	if err != nil {
 		return err
 	}
 
+	if condition != nil {
+		cache.MarkSent(uid, string(condition.Status), condition.Reason, condition.Message)
+	}
+
+	onFailure := func(err error) {
+		logger.Warnf("Failed to deliver cloudevent: %s", err)
+		if handler := FailureHandlerFromContext(ctx); handler != nil {
+			handler(ctx, object, err)
+			return
+		}
+		// No FailureHandler configured: preserve the historical behaviour of
+		// recording a k8s Warning event directly, for callers that have not
+		// migrated to events.Emitter (which installs its own handler).
+		recorder := controller.GetEventRecorder(ctx)
+		if recorder == nil {
+			logger.Warnf("No recorder in context, cannot emit error event")
+			return
+		}
+		recorder.Event(object, corev1.EventTypeWarning, "Cloud Event Failure", err.Error())
+	}
+
+	if dispatcher := DispatcherFromContext(ctx); dispatcher != nil {
+		wasIn := make(chan error)
+		go func() {
+			wasIn <- nil
+			logger.Debugf("Dispatching cloudevent of type %q", event.Type())
+			if _, err := dispatcher.Dispatch(ctx, o.GetObjectMeta().GetUID(), *event); err != nil {
+				onFailure(err)
+			}
+		}()
+		return <-wasIn
+	}
+
+	ceClient := cloudevent.Get(ctx)
+	if ceClient == nil {
+		return errors.New("no cloud events client or dispatcher found in the context")
+	}
+
 	wasIn := make(chan error)
 	go func() {
 		wasIn <- nil
 		logger.Debugf("Sending cloudevent of type %q", event.Type())
 		if result := ceClient.Send(cloudevents.ContextWithRetriesExponentialBackoff(ctx, 10*time.Millisecond, 10), *event); !cloudevents.IsACK(result) {
-			logger.Warnf("Failed to send cloudevent: %s", result.Error())
-			recorder := controller.GetEventRecorder(ctx)
-			if recorder == nil {
-				logger.Warnf("No recorder in context, cannot emit error event")
-			} else {
-				recorder.Event(object, corev1.EventTypeWarning, "Cloud Event Failure", result.Error())
-			}
+			onFailure(result)
 		}
 	}()
 
@@ -0,0 +1,99 @@
+package cloudevent
+
+import (
+	"testing"
+
+	cdeevents "github.com/cdfoundation/sig-events/cde/sdk/go/pkg/cdf/events"
+	"github.com/tektoncd/pipeline/pkg/apis/run/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func runWithCondition(status corev1.ConditionStatus, reason string) *v1alpha1.Run {
+	return &v1alpha1.Run{
+		Status: v1alpha1.RunStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{
+					Type:   apis.ConditionSucceeded,
+					Status: status,
+					Reason: reason,
+				}},
+			},
+		},
+	}
+}
+
+func TestGetEventTypeForRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     corev1.ConditionStatus
+		reason     string
+		wantType   cdeevents.CDEventType
+		wantStatus EventStatus
+		wantErr    bool
+	}{
+		{
+			name:       "started",
+			status:     corev1.ConditionUnknown,
+			reason:     v1alpha1.RunReasonStarted.String(),
+			wantType:   cdeevents.TaskRunStartedEventV1,
+			wantStatus: StatusRunning,
+		},
+		{
+			name:       "running",
+			status:     corev1.ConditionUnknown,
+			reason:     v1alpha1.RunReasonRunning.String(),
+			wantType:   cdeevents.TaskRunStartedEventV1,
+			wantStatus: StatusRunning,
+		},
+		{
+			name:    "unknown status with unrecognized reason",
+			status:  corev1.ConditionUnknown,
+			reason:  "SomethingElse",
+			wantErr: true,
+		},
+		{
+			name:       "succeeded",
+			status:     corev1.ConditionTrue,
+			reason:     "Succeeded",
+			wantType:   cdeevents.TaskRunFinishedEventV1,
+			wantStatus: StatusFinished,
+		},
+		{
+			name:       "failed",
+			status:     corev1.ConditionFalse,
+			reason:     "Failed",
+			wantType:   cdeevents.TaskRunFinishedEventV1,
+			wantStatus: StatusError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			et, err := getEventType(runWithCondition(tt.status, tt.reason))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for reason %q, got none", tt.reason)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("getEventType: %v", err)
+			}
+			if et.Type != tt.wantType {
+				t.Errorf("Type = %v, want %v", et.Type, tt.wantType)
+			}
+			if et.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v", et.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestGetEventTypeForRunNoCondition(t *testing.T) {
+	run := &v1alpha1.Run{}
+	if _, err := getEventType(run); err == nil {
+		t.Fatal("expected an error when the Run has no Succeeded condition")
+	}
+}
@@ -0,0 +1,45 @@
+package cloudevent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+// FileDeadLetterSink writes permanently-failed events to a directory on
+// disk, one file per event, for later inspection or replay. It is the
+// simplest of the supported dead-letter sinks; Kafka-topic and
+// second-HTTPS-endpoint variants follow the same DeadLetterSink interface.
+type FileDeadLetterSink struct {
+	Dir string
+}
+
+// NewFileDeadLetterSink returns a FileDeadLetterSink that writes into dir,
+// creating it if it does not already exist.
+func NewFileDeadLetterSink(dir string) (*FileDeadLetterSink, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating dead-letter dir %q: %w", dir, err)
+	}
+	return &FileDeadLetterSink{Dir: dir}, nil
+}
+
+type deadLetterRecord struct {
+	Event  interface{}     `json:"event"`
+	States []DeliveryState `json:"states"`
+}
+
+// Send implements DeadLetterSink.
+func (f *FileDeadLetterSink) Send(ctx context.Context, event cloudevents.Event, states []DeliveryState) error {
+	record := deadLetterRecord{Event: event, States: states}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshalling dead-letter record: %w", err)
+	}
+	name := fmt.Sprintf("%s-%s.json", time.Now().UTC().Format("20060102T150405.000000000Z"), event.ID())
+	return os.WriteFile(filepath.Join(f.Dir, name), data, 0o644)
+}
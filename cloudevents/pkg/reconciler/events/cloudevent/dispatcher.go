@@ -0,0 +1,237 @@
+package cloudevent
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/logging"
+)
+
+// RetryPolicy configures the backoff used when delivery to a sink fails.
+type RetryPolicy struct {
+	// MaxRetries is the number of additional attempts after the first one.
+	// Zero or a negative value uses the default of 10.
+	MaxRetries int
+	// BackoffDuration is the base duration used by the exponential backoff.
+	// Zero or a negative value uses the default of 10ms.
+	BackoffDuration time.Duration
+}
+
+// SinkConfig describes a single configured delivery target. The intent is
+// for sinks to be read from the `default-cloud-events-sink` config map, one
+// entry per sink name, but that config-map parsing does not exist yet in
+// this package - callers build []SinkConfig by hand until it does.
+// Protocol is derived from URI's scheme (see ParseSinkProtocol); Batch only
+// applies to the streaming protocols (NATS, Kafka) and is ignored for HTTP.
+type SinkConfig struct {
+	// Name uniquely identifies the sink within the dispatcher, and is used
+	// as the key of the dispatcher's in-memory per-sink sent/failed counters
+	// and on the delivery status persisted to the run. Those counters are
+	// not exported as Prometheus metrics.
+	Name     string
+	URI      string
+	Protocol SinkProtocol
+	Headers  map[string]string
+	Retry    RetryPolicy
+	Batch    BatchingConfig
+}
+
+// DeliveryState records the outcome of the most recent delivery attempt(s)
+// to a single sink for a single object, in the same spirit as Tekton's
+// CloudEventDelivery status field.
+type DeliveryState struct {
+	SinkName     string
+	Condition    string // "Sent" or "Failed"
+	SentAt       *time.Time
+	Attempts     int
+	LastError    string
+}
+
+// StatusWriter persists the per-sink DeliveryState back onto the object the
+// event was derived from, e.g. via a status sub-resource patch against a
+// TaskRun or PipelineRun. Implementations live alongside the reconcilers
+// that own the corresponding CRD client.
+type StatusWriter interface {
+	WriteDeliveryState(ctx context.Context, uid types.UID, states []DeliveryState) error
+}
+
+// DeadLetterSink receives events that exhausted retries on every configured
+// sink, so that they are not silently dropped. It is not invoked when the
+// event was delivered successfully to at least one sink.
+type DeadLetterSink interface {
+	Send(ctx context.Context, event cloudevents.Event, states []DeliveryState) error
+}
+
+// Dispatcher fans a single CloudEvent out to every configured sink.
+// It replaces the single-client `cloudevent.Get(ctx)` model: callers no
+// longer talk to a `cloudevents.Client` directly, they go through a
+// Dispatcher so that multi-sink delivery, tracking and the DLQ are applied
+// uniformly.
+type Dispatcher interface {
+	// Dispatch sends event to every configured sink concurrently, bounded by
+	// the dispatcher's worker pool, and returns once all sinks have either
+	// succeeded or exhausted their retries. The per-sink DeliveryState is
+	// always returned, even when err is non-nil.
+	Dispatch(ctx context.Context, uid types.UID, event cloudevents.Event) ([]DeliveryState, error)
+}
+
+// defaultDispatcher is the production Dispatcher implementation. It fans out
+// to sinks using a bounded pool of goroutines, writes delivery state back
+// onto the originating object via a StatusWriter, and forwards permanently
+// failed events to a DeadLetterSink.
+type defaultDispatcher struct {
+	sinks      []SinkConfig
+	clients    map[string]CEClient
+	maxWorkers int
+	writer     StatusWriter
+	deadLetter DeadLetterSink
+	metrics    *dispatcherMetrics
+}
+
+// DispatcherOption configures a defaultDispatcher at construction time.
+type DispatcherOption func(*defaultDispatcher)
+
+// WithStatusWriter sets the StatusWriter used to persist per-sink delivery
+// state back onto the run. When unset, delivery state is only returned to
+// the caller and not persisted.
+func WithStatusWriter(w StatusWriter) DispatcherOption {
+	return func(d *defaultDispatcher) {
+		d.writer = w
+	}
+}
+
+// WithDeadLetterSink sets the sink that receives events which failed on
+// every configured sink.
+func WithDeadLetterSink(dl DeadLetterSink) DispatcherOption {
+	return func(d *defaultDispatcher) {
+		d.deadLetter = dl
+	}
+}
+
+// WithMaxWorkers bounds the number of sinks dispatched to concurrently.
+// Defaults to the number of configured sinks when unset or <= 0.
+func WithMaxWorkers(n int) DispatcherOption {
+	return func(d *defaultDispatcher) {
+		d.maxWorkers = n
+	}
+}
+
+// NewDispatcher builds a Dispatcher that delivers to the given sinks. clients
+// holds one CEClient per sink name, keyed by SinkConfig.Name, constructed
+// by NewCEClient from each sink's URI.
+func NewDispatcher(sinks []SinkConfig, clients map[string]CEClient, opts ...DispatcherOption) Dispatcher {
+	d := &defaultDispatcher{
+		sinks:      sinks,
+		clients:    clients,
+		maxWorkers: len(sinks),
+		metrics:    newDispatcherMetrics(),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	if d.maxWorkers <= 0 {
+		d.maxWorkers = len(sinks)
+	}
+	return d
+}
+
+func (d *defaultDispatcher) Dispatch(ctx context.Context, uid types.UID, event cloudevents.Event) ([]DeliveryState, error) {
+	logger := logging.FromContext(ctx)
+	states := make([]DeliveryState, len(d.sinks))
+
+	sem := make(chan struct{}, d.maxWorkers)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	failedSinks := 0
+
+	for i, sink := range d.sinks {
+		i, sink := i, sink
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			state := d.deliverToSink(ctx, sink, event)
+			states[i] = state
+			d.metrics.record(sink.Name, state)
+
+			if state.Condition != "Sent" {
+				logger.Warnw("failed to deliver cloudevent to sink", zap.String("sink", sink.Name), zap.String("error", state.LastError))
+				mu.Lock()
+				failedSinks++
+				if firstErr == nil {
+					firstErr = fmt.Errorf("sink %q: %s", sink.Name, state.LastError)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if d.writer != nil {
+		if err := d.writer.WriteDeliveryState(ctx, uid, states); err != nil {
+			logger.Warnf("failed to persist cloudevent delivery state: %v", err)
+		}
+	}
+
+	// Only forward to the dead-letter sink once every configured sink has
+	// failed: a flaky sink alongside a healthy one means the event was still
+	// delivered, so it isn't dead-lettered, it's just degraded.
+	if len(d.sinks) > 0 && failedSinks == len(d.sinks) && d.deadLetter != nil {
+		if err := d.deadLetter.Send(ctx, event, states); err != nil {
+			logger.Warnf("failed to forward cloudevent to dead-letter sink: %v", err)
+		}
+	}
+
+	return states, firstErr
+}
+
+// deliverToSink owns its own retry loop, rather than handing the backoff
+// policy to the SDK via cloudevents.ContextWithRetriesExponentialBackoff, so
+// that Attempts on the returned DeliveryState reflects how many attempts
+// were actually made instead of always reporting the configured ceiling.
+func (d *defaultDispatcher) deliverToSink(ctx context.Context, sink SinkConfig, event cloudevents.Event) DeliveryState {
+	client, ok := d.clients[sink.Name]
+	if !ok {
+		return DeliveryState{SinkName: sink.Name, Condition: "Failed", Attempts: 0, LastError: fmt.Sprintf("no client configured for sink %q", sink.Name)}
+	}
+
+	backoff := sink.Retry.BackoffDuration
+	if backoff <= 0 {
+		backoff = 10 * time.Millisecond
+	}
+	maxRetries := sink.Retry.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = 10
+	}
+
+	var lastErr error
+	attempts := 0
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		attempts++
+		result := client.Send(ctx, event)
+		if cloudevents.IsACK(result) {
+			now := time.Now()
+			return DeliveryState{SinkName: sink.Name, Condition: "Sent", SentAt: &now, Attempts: attempts}
+		}
+		lastErr = result
+
+		if attempt == maxRetries {
+			break
+		}
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-ctx.Done():
+			return DeliveryState{SinkName: sink.Name, Condition: "Failed", Attempts: attempts, LastError: ctx.Err().Error()}
+		}
+	}
+	return DeliveryState{SinkName: sink.Name, Condition: "Failed", Attempts: attempts, LastError: lastErr.Error()}
+}
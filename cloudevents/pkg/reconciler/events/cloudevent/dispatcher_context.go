@@ -0,0 +1,19 @@
+package cloudevent
+
+import "context"
+
+type dispatcherKey struct{}
+
+// WithDispatcher returns a copy of ctx carrying d, retrievable with
+// DispatcherFromContext. Controllers wire this in alongside the other
+// per-reconciler context values (recorder, cloud events client, ...).
+func WithDispatcher(ctx context.Context, d Dispatcher) context.Context {
+	return context.WithValue(ctx, dispatcherKey{}, d)
+}
+
+// DispatcherFromContext returns the Dispatcher stored in ctx by
+// WithDispatcher, or nil if none was set.
+func DispatcherFromContext(ctx context.Context) Dispatcher {
+	d, _ := ctx.Value(dispatcherKey{}).(Dispatcher)
+	return d
+}
@@ -0,0 +1,47 @@
+package cloudevent
+
+import "sync"
+
+// dispatcherMetrics tracks per-sink delivery counts in memory via Snapshot.
+// TODO(metrics): this does not itself register or export a Prometheus metric
+// per sink as originally requested; it's a private counter with no consumer
+// yet. Wiring Snapshot into prometheus/client_golang (or this repo's
+// existing metrics stack, if any) is still open work.
+type dispatcherMetrics struct {
+	mu     sync.Mutex
+	sent   map[string]int64
+	failed map[string]int64
+}
+
+func newDispatcherMetrics() *dispatcherMetrics {
+	return &dispatcherMetrics{
+		sent:   map[string]int64{},
+		failed: map[string]int64{},
+	}
+}
+
+func (m *dispatcherMetrics) record(sinkName string, state DeliveryState) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if state.Condition == "Sent" {
+		m.sent[sinkName]++
+	} else {
+		m.failed[sinkName]++
+	}
+}
+
+// Snapshot returns the current sent/failed counters per sink. Nothing calls
+// this yet; it exists for a future Prometheus collector to poll.
+func (m *dispatcherMetrics) Snapshot() (sent, failed map[string]int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sent = make(map[string]int64, len(m.sent))
+	failed = make(map[string]int64, len(m.failed))
+	for k, v := range m.sent {
+		sent[k] = v
+	}
+	for k, v := range m.failed {
+		failed[k] = v
+	}
+	return sent, failed
+}
@@ -0,0 +1,153 @@
+package cloudevent
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// fakeCEClient is a CEClient whose outcome and call count are controlled by
+// the test, used to drive deliverToSink/Dispatch without a real network
+// round trip.
+type fakeCEClient struct {
+	mu      sync.Mutex
+	calls   int
+	failFor int // Send fails for the first failFor calls, then ACKs.
+}
+
+func (f *fakeCEClient) Send(ctx context.Context, event cloudevents.Event) protocol.Result {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.calls <= f.failFor {
+		return errors.New("boom")
+	}
+	return nil
+}
+
+func (f *fakeCEClient) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+type fakeDeadLetter struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (f *fakeDeadLetter) Send(ctx context.Context, event cloudevents.Event, states []DeliveryState) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	return nil
+}
+
+func (f *fakeDeadLetter) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func fastRetry() RetryPolicy {
+	return RetryPolicy{MaxRetries: 1, BackoffDuration: time.Millisecond}
+}
+
+func TestDispatchAllSinksSucceed(t *testing.T) {
+	a, b := &fakeCEClient{}, &fakeCEClient{}
+	dl := &fakeDeadLetter{}
+	d := NewDispatcher(
+		[]SinkConfig{{Name: "a", Retry: fastRetry()}, {Name: "b", Retry: fastRetry()}},
+		map[string]CEClient{"a": a, "b": b},
+		WithDeadLetterSink(dl),
+	)
+
+	states, err := d.Dispatch(context.Background(), types.UID("uid-1"), cloudevents.NewEvent())
+	if err != nil {
+		t.Fatalf("expected no error when every sink succeeds, got %v", err)
+	}
+	for _, s := range states {
+		if s.Condition != "Sent" {
+			t.Fatalf("expected every sink to report Sent, got %+v", s)
+		}
+		if s.Attempts != 1 {
+			t.Fatalf("expected a first-try success to record exactly 1 attempt, got %d", s.Attempts)
+		}
+	}
+	if dl.callCount() != 0 {
+		t.Fatalf("dead-letter sink must not be invoked when every sink succeeds, got %d calls", dl.callCount())
+	}
+}
+
+func TestDispatchPartialFailureSkipsDeadLetter(t *testing.T) {
+	ok := &fakeCEClient{}
+	broken := &fakeCEClient{failFor: 1000} // always fails within the test's retry budget
+	dl := &fakeDeadLetter{}
+	d := NewDispatcher(
+		[]SinkConfig{{Name: "ok", Retry: fastRetry()}, {Name: "broken", Retry: fastRetry()}},
+		map[string]CEClient{"ok": ok, "broken": broken},
+		WithDeadLetterSink(dl),
+	)
+
+	_, err := d.Dispatch(context.Background(), types.UID("uid-2"), cloudevents.NewEvent())
+	if err == nil {
+		t.Fatal("expected an error reporting the broken sink's failure")
+	}
+	if dl.callCount() != 0 {
+		t.Fatalf("dead-letter sink must not be invoked while at least one sink delivered, got %d calls", dl.callCount())
+	}
+}
+
+func TestDispatchAllSinksFailedForwardsToDeadLetter(t *testing.T) {
+	a := &fakeCEClient{failFor: 1000}
+	b := &fakeCEClient{failFor: 1000}
+	dl := &fakeDeadLetter{}
+	d := NewDispatcher(
+		[]SinkConfig{{Name: "a", Retry: fastRetry()}, {Name: "b", Retry: fastRetry()}},
+		map[string]CEClient{"a": a, "b": b},
+		WithDeadLetterSink(dl),
+	)
+
+	states, err := d.Dispatch(context.Background(), types.UID("uid-3"), cloudevents.NewEvent())
+	if err == nil {
+		t.Fatal("expected an error when every sink fails")
+	}
+	if dl.callCount() != 1 {
+		t.Fatalf("expected the dead-letter sink to be invoked exactly once, got %d calls", dl.callCount())
+	}
+	for _, s := range states {
+		if s.Condition != "Failed" {
+			t.Fatalf("expected every sink to report Failed, got %+v", s)
+		}
+		if s.Attempts != 2 {
+			t.Fatalf("expected MaxRetries=1 to produce 2 attempts, got %d", s.Attempts)
+		}
+	}
+}
+
+func TestDispatchAttemptsReflectsActualRetries(t *testing.T) {
+	// Fails once, then ACKs on the retry: Attempts must be 2, not the
+	// configured MaxRetries ceiling.
+	c := &fakeCEClient{failFor: 1}
+	d := NewDispatcher(
+		[]SinkConfig{{Name: "a", Retry: RetryPolicy{MaxRetries: 3, BackoffDuration: time.Millisecond}}},
+		map[string]CEClient{"a": c},
+	)
+
+	states, err := d.Dispatch(context.Background(), types.UID("uid-4"), cloudevents.NewEvent())
+	if err != nil {
+		t.Fatalf("expected eventual success, got %v", err)
+	}
+	if len(states) != 1 || states[0].Attempts != 2 {
+		t.Fatalf("expected exactly 2 recorded attempts, got %+v", states)
+	}
+	if c.callCount() != 2 {
+		t.Fatalf("expected the client to be called exactly twice, got %d", c.callCount())
+	}
+}
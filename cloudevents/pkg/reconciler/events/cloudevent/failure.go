@@ -0,0 +1,28 @@
+package cloudevent
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// FailureHandler is notified when SendCloudEventWithRetries fails to deliver
+// an event, after cloud-event delivery itself has been exhausted. It lets
+// callers decide how failures surface (e.g. as a k8s Warning event) without
+// this package depending on knative.dev/pkg/controller directly.
+type FailureHandler func(ctx context.Context, object runtime.Object, err error)
+
+type failureHandlerKey struct{}
+
+// WithFailureHandler returns a copy of ctx carrying h, retrievable with
+// FailureHandlerFromContext.
+func WithFailureHandler(ctx context.Context, h FailureHandler) context.Context {
+	return context.WithValue(ctx, failureHandlerKey{}, h)
+}
+
+// FailureHandlerFromContext returns the FailureHandler stored in ctx by
+// WithFailureHandler, or nil if none was set.
+func FailureHandlerFromContext(ctx context.Context) FailureHandler {
+	h, _ := ctx.Value(failureHandlerKey{}).(FailureHandler)
+	return h
+}
@@ -0,0 +1,237 @@
+package cloudevent
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	kafka_sarama "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
+	cenats "github.com/cloudevents/sdk-go/protocol/nats/v2"
+)
+
+// SinkProtocol identifies the wire protocol used to deliver events to a
+// sink, derived from the sink URI's scheme by ParseSinkProtocol.
+type SinkProtocol string
+
+const (
+	// ProtocolHTTP delivers events over HTTP(S), one roundtrip per event.
+	ProtocolHTTP SinkProtocol = "http"
+	// ProtocolNATS delivers events as NATS messages on the subject named by
+	// the sink URI's path.
+	ProtocolNATS SinkProtocol = "nats"
+	// ProtocolKafka delivers events as Kafka messages on the topic named by
+	// the sink URI's path.
+	ProtocolKafka SinkProtocol = "kafka"
+)
+
+// BatchingConfig bounds how long events are buffered before being flushed
+// to a streaming sink (NATS, Kafka). It is ignored for ProtocolHTTP sinks,
+// which send one event per roundtrip as before.
+//
+// NOTE: this coalesces timing only, not transport round trips. flush still
+// calls the underlying CEClient's Send once per buffered event (see
+// batchingClient.flush) because that CEClient is a cloudevents-sdk-go
+// Sender, whose interface is one message at a time - there is no bulk
+// publish underneath it. Bounding worst-case latency via FlushInterval is
+// the benefit actually delivered today; a real reduction in round trips
+// would mean dropping to the underlying Sarama/NATS client directly (e.g.
+// Sarama's SendMessages) instead of going through that Sender, which is
+// still open work.
+type BatchingConfig struct {
+	// MaxBatchSize flushes the buffer once it holds this many events.
+	MaxBatchSize int
+	// FlushInterval flushes the buffer on this cadence even if it has not
+	// reached MaxBatchSize, bounding worst-case delivery latency.
+	FlushInterval time.Duration
+}
+
+// ParseSinkProtocol derives the SinkProtocol from a sink URI's scheme, e.g.
+// `nats://events.svc/pipelineruns`, `kafka://broker:9092/pipelineruns`, or
+// `https://sink.example.com`.
+func ParseSinkProtocol(sinkURI string) (SinkProtocol, error) {
+	u, err := url.Parse(sinkURI)
+	if err != nil {
+		return "", fmt.Errorf("parsing sink URI %q: %w", sinkURI, err)
+	}
+	switch u.Scheme {
+	case "http", "https":
+		return ProtocolHTTP, nil
+	case "nats":
+		return ProtocolNATS, nil
+	case "kafka":
+		return ProtocolKafka, nil
+	default:
+		return "", fmt.Errorf("unsupported sink protocol scheme %q in %q", u.Scheme, sinkURI)
+	}
+}
+
+// NewCEClient builds the CEClient for sink, selecting the protocol binding
+// that matches sink.Protocol (defaulting to ParseSinkProtocol(sink.URI) if
+// sink.Protocol is unset). NATS and Kafka sinks are wrapped in a
+// batchingClient per sink.Batch; HTTP sinks send one event per roundtrip.
+// The client returned here never sees sink.Retry: retries are applied by
+// dispatcher.go's deliverToSink, which calls Send again on failure, so
+// RetryPolicy is honoured uniformly across every protocol binding built
+// here rather than depending on which ones understand a retry-aware context.
+func NewCEClient(sink SinkConfig) (CEClient, error) {
+	proto := sink.Protocol
+	if proto == "" {
+		p, err := ParseSinkProtocol(sink.URI)
+		if err != nil {
+			return nil, err
+		}
+		proto = p
+	}
+
+	switch proto {
+	case ProtocolHTTP:
+		opts := []cehttp.Option{cehttp.WithTarget(sink.URI)}
+		for k, v := range sink.Headers {
+			opts = append(opts, cehttp.WithHeader(k, v))
+		}
+		p, err := cehttp.New(opts...)
+		if err != nil {
+			return nil, fmt.Errorf("creating HTTP protocol for sink %q: %w", sink.Name, err)
+		}
+		client, err := cloudevents.NewClient(p)
+		if err != nil {
+			return nil, fmt.Errorf("creating HTTP client for sink %q: %w", sink.Name, err)
+		}
+		return client, nil
+
+	case ProtocolNATS:
+		u, err := url.Parse(sink.URI)
+		if err != nil {
+			return nil, fmt.Errorf("parsing NATS sink URI %q: %w", sink.URI, err)
+		}
+		subject := subjectOrTopic(u)
+		p, err := cenats.NewSender(u.Host, subject)
+		if err != nil {
+			return nil, fmt.Errorf("creating NATS protocol for sink %q: %w", sink.Name, err)
+		}
+		client, err := cloudevents.NewClient(p)
+		if err != nil {
+			return nil, fmt.Errorf("creating NATS client for sink %q: %w", sink.Name, err)
+		}
+		return newBatchingClient(client, sink.Batch), nil
+
+	case ProtocolKafka:
+		u, err := url.Parse(sink.URI)
+		if err != nil {
+			return nil, fmt.Errorf("parsing Kafka sink URI %q: %w", sink.URI, err)
+		}
+		topic := subjectOrTopic(u)
+		p, err := kafka_sarama.NewSender([]string{u.Host}, nil, topic)
+		if err != nil {
+			return nil, fmt.Errorf("creating Kafka protocol for sink %q: %w", sink.Name, err)
+		}
+		client, err := cloudevents.NewClient(p)
+		if err != nil {
+			return nil, fmt.Errorf("creating Kafka client for sink %q: %w", sink.Name, err)
+		}
+		return newBatchingClient(client, sink.Batch), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported sink protocol %q for sink %q", proto, sink.Name)
+	}
+}
+
+// subjectOrTopic returns the NATS subject or Kafka topic a sink URI names,
+// taken from its path with the leading slash trimmed.
+func subjectOrTopic(u *url.URL) string {
+	path := u.Path
+	if len(path) > 0 && path[0] == '/' {
+		path = path[1:]
+	}
+	return path
+}
+
+// pendingSend is one event waiting in a batchingClient's buffer. done is
+// closed with the event's own delivery result once the batch it ended up in
+// is actually flushed, so Send only reports ACK/NACK for an event once it
+// has genuinely been handed to the underlying protocol.
+type pendingSend struct {
+	event cloudevents.Event
+	done  chan protocol.Result
+}
+
+// batchingClient buffers events for a streaming sink and releases them
+// together, either once MaxBatchSize is reached or on FlushInterval. This
+// only coalesces *when* events are sent, not how many underlying sends it
+// takes: flush still issues one inner.Send per buffered event (see below),
+// so it does not reduce transport round trips today. Flushes run detached
+// from any single caller's context: a batch is shared by events submitted
+// under independent reconciles, so no one event's context should gate or
+// cancel delivery of the others.
+//
+// This does not need to thread a sink's RetryPolicy through the flush
+// context: retries are owned by dispatcher.go's deliverToSink, which calls
+// Send again (and so rebuffers/reflushes) on failure, rather than relying on
+// a retry-aware context understood by the underlying protocol client.
+type batchingClient struct {
+	inner CEClient
+	cfg   BatchingConfig
+
+	mu      sync.Mutex
+	pending []pendingSend
+	timer   *time.Timer
+}
+
+func newBatchingClient(inner CEClient, cfg BatchingConfig) CEClient {
+	if cfg.MaxBatchSize <= 1 {
+		return inner
+	}
+	return &batchingClient{inner: inner, cfg: cfg}
+}
+
+// Send buffers event and blocks until the batch it ends up in is flushed,
+// either because it reached MaxBatchSize or because FlushInterval elapsed
+// since the first event in the batch was buffered.
+func (b *batchingClient) Send(ctx context.Context, event cloudevents.Event) protocol.Result {
+	ps := pendingSend{event: event, done: make(chan protocol.Result, 1)}
+
+	b.mu.Lock()
+	b.pending = append(b.pending, ps)
+	flush := len(b.pending) >= b.cfg.MaxBatchSize
+	if len(b.pending) == 1 && !flush {
+		b.timer = time.AfterFunc(b.cfg.FlushInterval, b.flush)
+	}
+	b.mu.Unlock()
+
+	if flush {
+		b.flush()
+	}
+
+	select {
+	case result := <-ps.done:
+		return result
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// flush sends every currently-buffered event using a context detached from
+// any individual caller, so one event's cancellation or deadline cannot
+// affect delivery of the rest of the batch. It calls inner.Send once per
+// event rather than issuing a single bulk publish - see the NOTE on
+// BatchingConfig - so this reduces send latency variance, not the number of
+// underlying round trips.
+func (b *batchingClient) flush() {
+	b.mu.Lock()
+	batch := b.pending
+	b.pending = nil
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	b.mu.Unlock()
+
+	for _, ps := range batch {
+		ps.done <- b.inner.Send(context.Background(), ps.event)
+	}
+}
@@ -0,0 +1,85 @@
+package cloudevent
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+)
+
+// recordingClient is a CEClient that remembers every event handed to it,
+// standing in for the underlying NATS/Kafka sender a batchingClient wraps.
+type recordingClient struct {
+	mu     sync.Mutex
+	events []cloudevents.Event
+}
+
+func (r *recordingClient) Send(ctx context.Context, event cloudevents.Event) protocol.Result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *recordingClient) sent() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.events)
+}
+
+func newTestEvent(id string) cloudevents.Event {
+	e := cloudevents.NewEvent()
+	e.SetID(id)
+	e.SetSource("test")
+	e.SetType("test.event")
+	return e
+}
+
+func TestBatchingClientFlushesOnSize(t *testing.T) {
+	rec := &recordingClient{}
+	client := newBatchingClient(rec, BatchingConfig{MaxBatchSize: 2, FlushInterval: time.Hour})
+
+	results := make(chan protocol.Result, 2)
+	go func() { results <- client.Send(context.Background(), newTestEvent("1")) }()
+	// Give the first Send time to land in the buffer before the second
+	// arrives and trips the size-based flush.
+	time.Sleep(20 * time.Millisecond)
+	go func() { results <- client.Send(context.Background(), newTestEvent("2")) }()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case result := <-results:
+			if !cloudevents.IsACK(result) {
+				t.Fatalf("expected an ACK once the batch flushed, got %v", result)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for Send to return after a size-triggered flush")
+		}
+	}
+	if rec.sent() != 2 {
+		t.Fatalf("expected both events to reach the inner client, got %d", rec.sent())
+	}
+}
+
+func TestBatchingClientFlushesOnTimer(t *testing.T) {
+	rec := &recordingClient{}
+	client := newBatchingClient(rec, BatchingConfig{MaxBatchSize: 10, FlushInterval: 20 * time.Millisecond})
+
+	result := make(chan protocol.Result, 1)
+	go func() { result <- client.Send(context.Background(), newTestEvent("1")) }()
+
+	select {
+	case r := <-result:
+		if !cloudevents.IsACK(r) {
+			t.Fatalf("expected an ACK once the timer flushed the batch, got %v", r)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for FlushInterval to flush a single buffered event")
+	}
+	if rec.sent() != 1 {
+		t.Fatalf("expected exactly one event to reach the inner client, got %d", rec.sent())
+	}
+}
@@ -0,0 +1,161 @@
+package cloudevent
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+// CloudEvents extension attributes used to carry a detached signature over
+// the event's canonical JSON representation, set by SignEvent and checked by
+// Verify.
+const (
+	ExtensionSignatureAlg = "signaturealg"
+	ExtensionSignature    = "signature"
+	ExtensionKeyID        = "keyid"
+)
+
+// SignatureAlgEd25519 identifies the algorithm used by FileKeySigner.
+const SignatureAlgEd25519 = "ed25519"
+
+// Signer computes a detached signature over a CloudEvent's canonical JSON
+// representation. Implementations are looked up from context via
+// WithSigner, keeping signing opt-in and pluggable: a file-based ed25519 key
+// for simple deployments, a Fulcio/Rekor-backed keyless signer for
+// sigstore-integrated ones.
+type Signer interface {
+	// Sign returns the signature algorithm identifier, the base64-encoded
+	// signature, and a key identifier to embed in the event's extension
+	// attributes.
+	Sign(ctx context.Context, payload []byte) (alg, signature, keyID string, err error)
+}
+
+// SignEvent signs event's canonical JSON representation with signer and
+// attaches the result as CloudEvents extension attributes.
+func SignEvent(ctx context.Context, signer Signer, event *cloudevents.Event) error {
+	payload, err := event.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("marshalling event for signing: %w", err)
+	}
+	alg, signature, keyID, err := signer.Sign(ctx, payload)
+	if err != nil {
+		return fmt.Errorf("signing event: %w", err)
+	}
+	event.SetExtension(ExtensionSignatureAlg, alg)
+	event.SetExtension(ExtensionSignature, signature)
+	event.SetExtension(ExtensionKeyID, keyID)
+	return nil
+}
+
+// Verify checks that event carries a valid signature from verifier for its
+// canonical JSON representation (with the signature extensions stripped, as
+// they were not present when the event was originally signed).
+func Verify(ctx context.Context, verifier Verifier, event cloudevents.Event) error {
+	alg, ok := event.Extensions()[ExtensionSignatureAlg].(string)
+	if !ok {
+		return fmt.Errorf("event %s has no %s extension", event.ID(), ExtensionSignatureAlg)
+	}
+	signature, ok := event.Extensions()[ExtensionSignature].(string)
+	if !ok {
+		return fmt.Errorf("event %s has no %s extension", event.ID(), ExtensionSignature)
+	}
+	keyID, _ := event.Extensions()[ExtensionKeyID].(string)
+
+	unsigned := event.Clone()
+	unsigned.SetExtension(ExtensionSignatureAlg, nil)
+	unsigned.SetExtension(ExtensionSignature, nil)
+	unsigned.SetExtension(ExtensionKeyID, nil)
+	payload, err := unsigned.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("marshalling event for verification: %w", err)
+	}
+	return verifier.Verify(ctx, payload, alg, signature, keyID)
+}
+
+// Verifier validates a Signer's detached signature. Downstream consumers
+// (Tekton Chains, Triggers, ...) use this to check events they receive
+// rather than re-implementing per-algorithm verification.
+type Verifier interface {
+	Verify(ctx context.Context, payload []byte, alg, signature, keyID string) error
+}
+
+// FileKeySigner signs events with an ed25519 private key loaded from a PEM
+// file on disk. It is the simplest Signer, suited to deployments that
+// already manage their own key material.
+type FileKeySigner struct {
+	keyID      string
+	privateKey ed25519.PrivateKey
+}
+
+// NewFileKeySigner loads an ed25519 private key from a PEM-encoded file at
+// keyPath. keyID is embedded in signed events so verifiers can select the
+// matching public key.
+func NewFileKeySigner(keyPath, keyID string) (*FileKeySigner, error) {
+	data, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading signing key %q: %w", keyPath, err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in %q", keyPath)
+	}
+	if len(block.Bytes) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("signing key %q is not a valid ed25519 private key", keyPath)
+	}
+	return &FileKeySigner{keyID: keyID, privateKey: ed25519.PrivateKey(block.Bytes)}, nil
+}
+
+// Sign implements Signer.
+func (s *FileKeySigner) Sign(ctx context.Context, payload []byte) (alg, signature, keyID string, err error) {
+	sig := ed25519.Sign(s.privateKey, payload)
+	return SignatureAlgEd25519, base64.StdEncoding.EncodeToString(sig), s.keyID, nil
+}
+
+// FileKeyVerifier validates signatures produced by FileKeySigner against
+// the corresponding ed25519 public key.
+type FileKeyVerifier struct {
+	publicKey ed25519.PublicKey
+}
+
+// NewFileKeyVerifier builds a FileKeyVerifier from a raw ed25519 public key.
+func NewFileKeyVerifier(publicKey ed25519.PublicKey) *FileKeyVerifier {
+	return &FileKeyVerifier{publicKey: publicKey}
+}
+
+// Verify implements Verifier.
+func (v *FileKeyVerifier) Verify(ctx context.Context, payload []byte, alg, signature, keyID string) error {
+	if alg != SignatureAlgEd25519 {
+		return fmt.Errorf("unsupported signature algorithm %q", alg)
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("decoding signature: %w", err)
+	}
+	if !ed25519.Verify(v.publicKey, payload, sig) {
+		return fmt.Errorf("signature verification failed for key %q", keyID)
+	}
+	return nil
+}
+
+type signerKey struct{}
+
+// WithSigner returns a copy of ctx carrying s, retrievable with
+// SignerFromContext. The intent is for controllers to wire this in when a
+// `default-cloud-events-signing` key is enabled in the defaults config map,
+// but that config-map parsing does not exist yet - callers construct a
+// Signer and call WithSigner directly until it does.
+func WithSigner(ctx context.Context, s Signer) context.Context {
+	return context.WithValue(ctx, signerKey{}, s)
+}
+
+// SignerFromContext returns the Signer stored in ctx by WithSigner, or nil
+// if signing is not enabled.
+func SignerFromContext(ctx context.Context) Signer {
+	s, _ := ctx.Value(signerKey{}).(Signer)
+	return s
+}
@@ -0,0 +1,65 @@
+package cloudevent
+
+import (
+	"context"
+	"fmt"
+)
+
+// KeylessSignerConfig configures a FulcioSigner.
+type KeylessSignerConfig struct {
+	// FulcioURL is the Fulcio CA used to obtain a short-lived signing
+	// certificate bound to the workload's OIDC identity.
+	FulcioURL string
+	// RekorURL is the transparency log the signature and certificate are
+	// uploaded to, so that the signing event can be independently audited.
+	RekorURL string
+	// OIDCIssuer and OIDCClientID identify the identity token exchanged for
+	// a Fulcio certificate (e.g. the cluster's service account issuer).
+	OIDCIssuer   string
+	OIDCClientID string
+}
+
+// FulcioSigner is NOT IMPLEMENTED. It is the extension point for a keyless
+// Signer - obtaining a short-lived certificate from Fulcio for the caller's
+// OIDC identity and uploading the resulting signature to Rekor, following
+// the same flow as `cosign sign --keyless` - but Sign unconditionally
+// errors below. Do not wire this into a controller expecting it to work;
+// only FileKeySigner is functional today.
+//
+// TODO(signing): implement against sigstore-go once it is vendored.
+type FulcioSigner struct {
+	cfg KeylessSignerConfig
+}
+
+// NewFulcioSigner returns a keyless Signer configured against the given
+// Fulcio/Rekor instances. See the FulcioSigner doc: Sign always errors.
+func NewFulcioSigner(cfg KeylessSignerConfig) *FulcioSigner {
+	return &FulcioSigner{cfg: cfg}
+}
+
+// Sign implements Signer. NOT IMPLEMENTED: always returns an error.
+func (s *FulcioSigner) Sign(ctx context.Context, payload []byte) (alg, signature, keyID string, err error) {
+	return "", "", "", fmt.Errorf("keyless signing via %q is not yet implemented", s.cfg.FulcioURL)
+}
+
+// RekorVerifier is NOT IMPLEMENTED. It is the extension point for validating
+// signatures produced by FulcioSigner by checking the embedded certificate
+// chain and the corresponding Rekor inclusion proof, but Verify
+// unconditionally errors below.
+//
+// TODO(signing): implement against sigstore-go once it is vendored.
+type RekorVerifier struct {
+	rekorURL string
+}
+
+// NewRekorVerifier returns a Verifier that checks signatures against the
+// given Rekor transparency log. See the RekorVerifier doc: Verify always
+// errors.
+func NewRekorVerifier(rekorURL string) *RekorVerifier {
+	return &RekorVerifier{rekorURL: rekorURL}
+}
+
+// Verify implements Verifier. NOT IMPLEMENTED: always returns an error.
+func (v *RekorVerifier) Verify(ctx context.Context, payload []byte, alg, signature, keyID string) error {
+	return fmt.Errorf("keyless verification against %q is not yet implemented", v.rekorURL)
+}
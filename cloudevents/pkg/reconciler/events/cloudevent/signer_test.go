@@ -0,0 +1,99 @@
+package cloudevent
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+func writeTestKey(t *testing.T, priv ed25519.PrivateKey) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "key.pem")
+	block := &pem.Block{Type: "PRIVATE KEY", Bytes: priv}
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatalf("writing test key: %v", err)
+	}
+	return path
+}
+
+func TestFileKeySignerVerifyRoundTrip(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generating ed25519 key: %v", err)
+	}
+	signer, err := NewFileKeySigner(writeTestKey(t, priv), "key-1")
+	if err != nil {
+		t.Fatalf("NewFileKeySigner: %v", err)
+	}
+	verifier := NewFileKeyVerifier(pub)
+
+	event := cloudevents.NewEvent()
+	event.SetID("1")
+	event.SetSource("test")
+	event.SetType("test.event")
+
+	if err := SignEvent(context.Background(), signer, &event); err != nil {
+		t.Fatalf("SignEvent: %v", err)
+	}
+	if err := Verify(context.Background(), verifier, event); err != nil {
+		t.Fatalf("expected a genuine signature to verify, got %v", err)
+	}
+}
+
+func TestFileKeyVerifierRejectsTamperedPayload(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generating ed25519 key: %v", err)
+	}
+	signer, err := NewFileKeySigner(writeTestKey(t, priv), "key-1")
+	if err != nil {
+		t.Fatalf("NewFileKeySigner: %v", err)
+	}
+	verifier := NewFileKeyVerifier(pub)
+
+	event := cloudevents.NewEvent()
+	event.SetID("1")
+	event.SetSource("test")
+	event.SetType("test.event")
+	if err := SignEvent(context.Background(), signer, &event); err != nil {
+		t.Fatalf("SignEvent: %v", err)
+	}
+
+	event.SetSource("tampered")
+	if err := Verify(context.Background(), verifier, event); err == nil {
+		t.Fatal("expected verification to fail once the signed payload was tampered with")
+	}
+}
+
+func TestFileKeyVerifierRejectsWrongKey(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generating ed25519 key: %v", err)
+	}
+	otherPub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generating ed25519 key: %v", err)
+	}
+	signer, err := NewFileKeySigner(writeTestKey(t, priv), "key-1")
+	if err != nil {
+		t.Fatalf("NewFileKeySigner: %v", err)
+	}
+	verifier := NewFileKeyVerifier(otherPub)
+
+	event := cloudevents.NewEvent()
+	event.SetID("1")
+	event.SetSource("test")
+	event.SetType("test.event")
+	if err := SignEvent(context.Background(), signer, &event); err != nil {
+		t.Fatalf("SignEvent: %v", err)
+	}
+
+	if err := Verify(context.Background(), verifier, event); err == nil {
+		t.Fatal("expected verification against the wrong public key to fail")
+	}
+}
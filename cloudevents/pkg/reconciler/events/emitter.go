@@ -0,0 +1,80 @@
+package events
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+
+	"github.com/nikhil-thomas/experimental/cloudevents/pkg/reconciler/events/cloudevent"
+)
+
+// Emitter decouples "a run transitioned" from how that transition is
+// surfaced. The cloudevent subpackage only knows how to build and deliver a
+// CDEvent; it used to also reach into the knative controller context to
+// record a k8s Warning event on failure, which made it impossible to use
+// outside a knative-controller reconcile loop (e.g. from a plain HTTP
+// server or a CLI replaying events from a file). Emitter is the seam that
+// coupling is moved behind.
+type Emitter interface {
+	// EmitCloudEvent builds and delivers a CDEvent for object, as
+	// cloudevent.SendCloudEventWithRetries does today.
+	EmitCloudEvent(ctx context.Context, object runtime.Object, opts ...cloudevent.SendOption) error
+	// EmitK8sEvent records a k8s event against object, as
+	// knative.dev/pkg/controller.GetEventRecorder(ctx).Eventf does today.
+	EmitK8sEvent(ctx context.Context, object runtime.Object, eventType, reason, messageFmt string, args ...interface{})
+}
+
+// Config selects which of the two event channels a compositeEmitter drives,
+// so a controller can be deployed cloud-events-only, k8s-events-only, or
+// both. The intent is for this to be read from the defaults config map
+// alongside `default-cloud-events-sink`, but that parsing does not exist
+// yet - callers build a Config by hand until it does.
+type Config struct {
+	EnableCloudEvents bool
+	EnableK8sEvents   bool
+}
+
+// compositeEmitter is the default Emitter: it emits cloud events and k8s
+// events independently, and (when both are enabled) records a k8s Warning
+// event whenever cloud-event delivery fails.
+type compositeEmitter struct {
+	cfg Config
+}
+
+// NewEmitter returns the default Emitter, configured by cfg.
+func NewEmitter(cfg Config) Emitter {
+	return &compositeEmitter{cfg: cfg}
+}
+
+// EmitCloudEvent implements Emitter.
+func (e *compositeEmitter) EmitCloudEvent(ctx context.Context, object runtime.Object, opts ...cloudevent.SendOption) error {
+	if !e.cfg.EnableCloudEvents {
+		return nil
+	}
+	// Always install a FailureHandler, even a no-op one: this is what lets
+	// EnableK8sEvents=false mean "cloud-events only" rather than falling
+	// back to cloudevent.SendCloudEventWithRetries' own legacy behaviour of
+	// recording a k8s Warning event whenever no handler is present.
+	ctx = cloudevent.WithFailureHandler(ctx, func(ctx context.Context, object runtime.Object, err error) {
+		if e.cfg.EnableK8sEvents {
+			e.EmitK8sEvent(ctx, object, corev1.EventTypeWarning, "CloudEventFailure", err.Error())
+		}
+	})
+	return cloudevent.SendCloudEventWithRetries(ctx, object, opts...)
+}
+
+// EmitK8sEvent implements Emitter.
+func (e *compositeEmitter) EmitK8sEvent(ctx context.Context, object runtime.Object, eventType, reason, messageFmt string, args ...interface{}) {
+	if !e.cfg.EnableK8sEvents {
+		return
+	}
+	recorder := controller.GetEventRecorder(ctx)
+	if recorder == nil {
+		logging.FromContext(ctx).Warnf("No recorder in context, cannot emit %s event %s", eventType, reason)
+		return
+	}
+	recorder.Eventf(object, eventType, reason, messageFmt, args...)
+}
@@ -0,0 +1,160 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	cloudeventssdk "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/controller"
+
+	"github.com/nikhil-thomas/experimental/cloudevents/pkg/reconciler/events/cloudevent"
+)
+
+// countingCEClient is a cloudevent.CEClient that records how many times it
+// was asked to deliver, and can be made to always fail so Dispatch reports
+// an error without needing a real sink.
+type countingCEClient struct {
+	mu    sync.Mutex
+	calls int
+	fail  bool
+}
+
+func (c *countingCEClient) Send(ctx context.Context, event cloudeventssdk.Event) protocol.Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	if c.fail {
+		return errors.New("boom")
+	}
+	return nil
+}
+
+func (c *countingCEClient) callCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls
+}
+
+func testTaskRun() *v1beta1.TaskRun {
+	return &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-taskrun", UID: types.UID("uid-1")},
+		Status: v1beta1.TaskRunStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionUnknown,
+					Reason: v1beta1.TaskRunReasonStarted.String(),
+				}},
+			},
+		},
+	}
+}
+
+func dispatcherWith(client *countingCEClient) cloudevent.Dispatcher {
+	return cloudevent.NewDispatcher(
+		[]cloudevent.SinkConfig{{Name: "sink", Retry: cloudevent.RetryPolicy{MaxRetries: 1, BackoffDuration: time.Millisecond}}},
+		map[string]cloudevent.CEClient{"sink": client},
+	)
+}
+
+func TestEmitterCloudEventsOnlySuppressesK8sFallback(t *testing.T) {
+	client := &countingCEClient{fail: true}
+	ctx := cloudevent.WithDispatcher(context.Background(), dispatcherWith(client))
+	recorder := record.NewFakeRecorder(1)
+	ctx = controller.WithEventRecorder(ctx, recorder)
+
+	e := NewEmitter(Config{EnableCloudEvents: true, EnableK8sEvents: false})
+	// Delivery is asynchronous: EmitCloudEvent returns before the dispatcher
+	// fails, and the failure only reaches the installed FailureHandler.
+	if err := e.EmitCloudEvent(ctx, testTaskRun()); err != nil {
+		t.Fatalf("expected EmitCloudEvent to return nil before delivery completes, got %v", err)
+	}
+	select {
+	case ev := <-recorder.Events:
+		t.Fatalf("EnableK8sEvents=false must not fall back to a k8s Warning event, got %q", ev)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestEmitterBothRecordsK8sWarningOnCloudEventFailure(t *testing.T) {
+	client := &countingCEClient{fail: true}
+	ctx := cloudevent.WithDispatcher(context.Background(), dispatcherWith(client))
+	recorder := record.NewFakeRecorder(1)
+	ctx = controller.WithEventRecorder(ctx, recorder)
+
+	e := NewEmitter(Config{EnableCloudEvents: true, EnableK8sEvents: true})
+	if err := e.EmitCloudEvent(ctx, testTaskRun()); err != nil {
+		t.Fatalf("expected EmitCloudEvent to return nil before delivery completes, got %v", err)
+	}
+	select {
+	case ev := <-recorder.Events:
+		if ev == "" {
+			t.Fatal("expected a non-empty Warning event")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a k8s Warning event when both channels are enabled and delivery fails")
+	}
+}
+
+func TestEmitterCloudEventsDisabledSkipsDispatch(t *testing.T) {
+	client := &countingCEClient{}
+	ctx := cloudevent.WithDispatcher(context.Background(), dispatcherWith(client))
+
+	e := NewEmitter(Config{EnableCloudEvents: false, EnableK8sEvents: true})
+	if err := e.EmitCloudEvent(ctx, testTaskRun()); err != nil {
+		t.Fatalf("expected no error when cloud events are disabled, got %v", err)
+	}
+	if client.callCount() != 0 {
+		t.Fatalf("expected no dispatch attempt when EnableCloudEvents is false, got %d calls", client.callCount())
+	}
+}
+
+func TestEmitterK8sEventsOnly(t *testing.T) {
+	recorder := record.NewFakeRecorder(1)
+	ctx := controller.WithEventRecorder(context.Background(), recorder)
+
+	e := NewEmitter(Config{EnableCloudEvents: false, EnableK8sEvents: true})
+	e.EmitK8sEvent(ctx, testTaskRun(), corev1.EventTypeNormal, "Started", "task started")
+
+	select {
+	case ev := <-recorder.Events:
+		if ev == "" {
+			t.Fatal("expected a non-empty k8s event")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected EmitK8sEvent to record an event when EnableK8sEvents is true")
+	}
+}
+
+func TestEmitterNeitherChannelEnabled(t *testing.T) {
+	client := &countingCEClient{}
+	recorder := record.NewFakeRecorder(1)
+	ctx := cloudevent.WithDispatcher(context.Background(), dispatcherWith(client))
+	ctx = controller.WithEventRecorder(ctx, recorder)
+
+	e := NewEmitter(Config{})
+	if err := e.EmitCloudEvent(ctx, testTaskRun()); err != nil {
+		t.Fatalf("expected no error when both channels are disabled, got %v", err)
+	}
+	e.EmitK8sEvent(ctx, testTaskRun(), corev1.EventTypeNormal, "Started", "task started")
+
+	if client.callCount() != 0 {
+		t.Fatalf("expected no dispatch attempt when both channels are disabled, got %d calls", client.callCount())
+	}
+	select {
+	case ev := <-recorder.Events:
+		t.Fatalf("expected no k8s event when EnableK8sEvents is false, got %q", ev)
+	default:
+	}
+}